@@ -0,0 +1,197 @@
+package redpanda
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/api/admin"
+)
+
+// TopicSpec describes a topic that should be created automatically once the
+// cluster is ready to serve requests. See WithAutoCreateTopics().
+type TopicSpec struct {
+	// Name is the topic name.
+	Name string
+
+	// Partitions is the number of partitions the topic is created with.
+	Partitions int
+
+	// ReplicationFactor is the number of replicas each partition is created
+	// with.
+	ReplicationFactor int
+}
+
+// WithAutoCreateTopics configures one or more topics to be created via the
+// Admin API right after the cluster reports healthy, so tests can rely on
+// them existing without a separate setup step.
+func WithAutoCreateTopics(topics ...TopicSpec) Option {
+	return func(o *options) {
+		o.AutoCreateTopics = append(o.AutoCreateTopics, topics...)
+	}
+}
+
+// SchemaPayload is the schema registered via Container.RegisterSchema().
+type SchemaPayload struct {
+	// Schema is the schema definition itself, for example an Avro schema
+	// encoded as JSON.
+	Schema string
+
+	// SchemaType is one of "AVRO", "JSON" or "PROTOBUF". Defaults to "AVRO"
+	// when left empty, matching the Schema Registry's own default.
+	SchemaType string
+}
+
+// adminAPIClient returns an Admin API client for the given mapped host/port,
+// shared by StartContainer()'s service account/topic bootstrapping and
+// Container.CreateACL(). tlsConfig is nil unless the container was started
+// with WithTLS(), in which case the admin listener is TLS-only and the
+// client must dial it with "https" and the matching trust root.
+func adminAPIClient(hostIP string, adminAPIPort int, tlsConfig *tls.Config) (*admin.AdminAPI, error) {
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+
+	adminAPIUrl := fmt.Sprintf("%s://%v:%d", scheme, hostIP, adminAPIPort)
+	adminCl, err := admin.NewAdminAPI([]string{adminAPIUrl}, admin.BasicCredentials{}, tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new admin api client: %w", err)
+	}
+	return adminCl, nil
+}
+
+// createTopics creates every topic configured via WithAutoCreateTopics().
+func createTopics(ctx context.Context, adminCl *admin.AdminAPI, topics []TopicSpec) error {
+	for _, topic := range topics {
+		err := adminCl.CreateTopic(ctx, admin.TopicConfiguration{
+			Name:              topic.Name,
+			NumPartitions:     topic.Partitions,
+			ReplicationFactor: topic.ReplicationFactor,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create topic %q: %w", topic.Name, err)
+		}
+	}
+	return nil
+}
+
+// CreateACL creates a single Kafka ACL via the Admin API. This is typically
+// used after WithNewServiceAccount() to authorize a service account created
+// for a test, for example to allow it to produce/consume on a given topic.
+func (c *Container) CreateACL(ctx context.Context, acl admin.CreateACLRequest) error {
+	adminCl, err := c.adminAPIClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create admin api client: %w", err)
+	}
+
+	if err := adminCl.CreateACLs(ctx, []admin.CreateACLRequest{acl}); err != nil {
+		return fmt.Errorf("failed to create ACL: %w", err)
+	}
+
+	return nil
+}
+
+// adminAPIClient returns an Admin API client pointed at this container's
+// mapped Admin API port, TLS-aware if the container was started with
+// WithTLS().
+func (c *Container) adminAPIClient(ctx context.Context) (*admin.AdminAPI, error) {
+	hostIP, err := c.Host(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container host: %w", err)
+	}
+
+	mappedPort, err := c.MappedPort(ctx, nat.Port(defaultAdminAPIPort))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mapped Admin API port: %w", err)
+	}
+
+	var tlsConfig *tls.Config
+	if c.tlsConfig != nil {
+		tlsConfig, err = c.TLSConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return adminAPIClient(hostIP, mappedPort.Int(), tlsConfig)
+}
+
+// ListTopics returns the names of every topic currently known to the
+// cluster, fetched via the Admin API. It's mainly useful in tests that
+// verify a topic created via WithAutoCreateTopics() (or a prior
+// Container.Restart()) is still present.
+func (c *Container) ListTopics(ctx context.Context) ([]string, error) {
+	adminCl, err := c.adminAPIClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create admin api client: %w", err)
+	}
+
+	topics, err := adminCl.ListTopics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topics: %w", err)
+	}
+
+	return topics, nil
+}
+
+// RegisterSchema registers schema under subject with the Schema Registry.
+// If the container was started with WithEnableSchemaRegistryHTTPBasicAuth(),
+// it authenticates with HTTP basic credentials as the service account named
+// there, using the password registered for it via WithNewServiceAccount().
+func (c *Container) RegisterSchema(ctx context.Context, subject string, schema SchemaPayload) error {
+	schemaRegistryAddr, err := c.SchemaRegistryAddress(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get schema registry address: %w", err)
+	}
+
+	schemaType := schema.SchemaType
+	if schemaType == "" {
+		schemaType = "AVRO"
+	}
+
+	reqBody, err := json.Marshal(map[string]string{
+		"schema":     schema.Schema,
+		"schemaType": schemaType,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema payload: %w", err)
+	}
+
+	reqURL := fmt.Sprintf("%s/subjects/%s/versions", schemaRegistryAddr, url.PathEscape(subject))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("failed to create schema registry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+
+	if c.schemaRegistryUsername != "" {
+		req.SetBasicAuth(c.schemaRegistryUsername, c.schemaRegistryPassword)
+	}
+
+	httpClient := http.DefaultClient
+	if c.tlsConfig != nil {
+		tlsConfig, err := c.TLSConfig()
+		if err != nil {
+			return err
+		}
+		httpClient = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to register schema: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to register schema: schema registry returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package redpanda_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redpanda-data/redpanda/src/go/rpk/pkg/api/admin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/redpanda"
+)
+
+func TestContainerCreateACL(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := redpanda.StartContainer(
+		ctx,
+		redpanda.WithEnableKafkaAuthorization(),
+		redpanda.WithNewServiceAccount("superuser-1", "test"),
+		redpanda.WithSuperusers("superuser-1"),
+		redpanda.WithAutoCreateTopics(redpanda.TopicSpec{Name: "acl-topic", Partitions: 1, ReplicationFactor: 1}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	err = container.CreateACL(ctx, admin.CreateACLRequest{
+		Resource:            admin.ResourceTopic,
+		ResourceName:        "acl-topic",
+		ResourcePatternType: admin.PatternTypeLiteral,
+		Operation:           admin.OperationRead,
+		Principal:           "User:superuser-1",
+		Host:                "*",
+		Permission:          admin.PermissionAllow,
+	})
+	require.NoError(t, err)
+}
+
+// TestContainerRegisterSchemaExplicitCredential verifies RegisterSchema
+// authenticates with the service account explicitly named via
+// WithEnableSchemaRegistryHTTPBasicAuth(), not an implicitly-chosen one.
+func TestContainerRegisterSchemaExplicitCredential(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := redpanda.StartContainer(
+		ctx,
+		redpanda.WithNewServiceAccount("sr-user", "test"),
+		redpanda.WithNewServiceAccount("other-user", "test"),
+		redpanda.WithEnableSchemaRegistryHTTPBasicAuth("sr-user"),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	err = container.RegisterSchema(ctx, "acl-topic-value", redpanda.SchemaPayload{
+		Schema: `{"type":"record","name":"Test","fields":[{"name":"id","type":"string"}]}`,
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,209 @@
+package redpanda
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// clusterRPCPort is the port Redpanda's internal RPC protocol (used for
+// replication and controller quorum) listens on.
+const clusterRPCPort = 33145
+
+// clusterNodeOptions wires a single StartContainer() call into a multi-node
+// Cluster: which Docker network/alias the node joins, its node ID, and the
+// seed servers it should dial on boot. It's only ever set by StartCluster(),
+// not by module users directly.
+type clusterNodeOptions struct {
+	networkName string
+	alias       string
+	nodeID      int
+	seedServers []clusterSeedServer
+}
+
+// clusterSeedServer is the Docker network alias of a cluster's seed node,
+// reachable on clusterRPCPort.
+type clusterSeedServer struct {
+	alias string
+}
+
+// withClusterNode is an internal Option used by StartCluster() to place a
+// node on a shared Docker network with a deterministic node ID and seed
+// servers pointing at the cluster's first node.
+func withClusterNode(networkName, alias string, nodeID int, seedServers []clusterSeedServer) Option {
+	return func(o *options) {
+		o.cluster = &clusterNodeOptions{
+			networkName: networkName,
+			alias:       alias,
+			nodeID:      nodeID,
+			seedServers: seedServers,
+		}
+	}
+}
+
+// Cluster represents a multi-node Redpanda cluster. Every node runs on a
+// shared Docker network so they can reach each other on the RPC port for
+// replication and controller quorum, in addition to being individually
+// reachable from the test host via their own mapped Kafka API ports.
+type Cluster struct {
+	// Nodes holds one Container per cluster member, in join order. Nodes[0]
+	// is the cluster's seed node.
+	Nodes []*Container
+
+	network *testcontainers.DockerNetwork
+}
+
+// StartCluster starts a Redpanda cluster of n nodes on a shared Docker
+// network. Node 0 is the cluster's seed server: every other node's
+// seed_servers configuration points at its RPC address. Terminate must be
+// called on the returned Cluster when it's no longer needed.
+func StartCluster(ctx context.Context, n int, opts ...Option) (*Cluster, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("a redpanda cluster needs at least 1 node, got %d", n)
+	}
+
+	net, err := network.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker network for cluster: %w", err)
+	}
+
+	seedServers := []clusterSeedServer{{alias: clusterNodeAlias(0)}}
+
+	cluster := &Cluster{network: net}
+	for i := 0; i < n; i++ {
+		nodeOpts := make([]Option, 0, len(opts)+1)
+		nodeOpts = append(nodeOpts, opts...)
+
+		var nodeSeedServers []clusterSeedServer
+		if i > 0 {
+			nodeSeedServers = seedServers
+		}
+		nodeOpts = append(nodeOpts, withClusterNode(net.Name, clusterNodeAlias(i), i, nodeSeedServers))
+
+		node, err := StartContainer(ctx, nodeOpts...)
+		if err != nil {
+			cluster.cleanupFailedStart(ctx)
+			return nil, fmt.Errorf("failed to start cluster node %d: %w", i, err)
+		}
+		cluster.Nodes = append(cluster.Nodes, node)
+	}
+
+	if err := cluster.waitUntilHealthy(ctx); err != nil {
+		cluster.cleanupFailedStart(ctx)
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// cleanupFailedStart terminates any nodes already started and removes the
+// cluster's Docker network, used when StartCluster fails partway through so
+// it doesn't leak containers/network across CI runs. Best-effort: errors are
+// discarded in favor of the original failure StartCluster returns.
+func (cl *Cluster) cleanupFailedStart(ctx context.Context) {
+	for _, node := range cl.Nodes {
+		_ = node.Terminate(ctx)
+	}
+	if cl.network != nil {
+		_ = cl.network.Remove(ctx)
+	}
+}
+
+// clusterNodeAlias returns the deterministic Docker network alias (and
+// hostname) for the i-th node of a cluster.
+func clusterNodeAlias(i int) string {
+	return fmt.Sprintf("redpanda-%d", i)
+}
+
+// KafkaSeedBrokers returns the comma-separated "host:port" bootstrap string
+// for every node in the cluster, as accepted by most Kafka client libraries.
+func (cl *Cluster) KafkaSeedBrokers(ctx context.Context) (string, error) {
+	brokers := make([]string, 0, len(cl.Nodes))
+	for i, node := range cl.Nodes {
+		broker, err := node.KafkaSeedBroker(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get seed broker for node %d: %w", i, err)
+		}
+		brokers = append(brokers, broker)
+	}
+	return strings.Join(brokers, ","), nil
+}
+
+// Rolling restarts each node of the cluster one at a time: it stops the
+// node, invokes fn (which may swap the image, mutate the data volume, etc.),
+// starts the node again, and waits for the whole cluster to report healthy
+// before moving on to the next node. fn may be nil.
+func (cl *Cluster) Rolling(ctx context.Context, fn func(ctx context.Context, node *Container) error) error {
+	for i, node := range cl.Nodes {
+		if err := node.Stop(ctx, nil); err != nil {
+			return fmt.Errorf("failed to stop node %d: %w", i, err)
+		}
+
+		if fn != nil {
+			if err := fn(ctx, node); err != nil {
+				return fmt.Errorf("rolling restart callback failed for node %d: %w", i, err)
+			}
+		}
+
+		if err := node.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start node %d: %w", i, err)
+		}
+
+		if err := cl.waitUntilHealthy(ctx); err != nil {
+			return fmt.Errorf("cluster did not become healthy after restarting node %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Terminate stops and removes every node, plus the cluster's shared Docker
+// network.
+func (cl *Cluster) Terminate(ctx context.Context) error {
+	for i, node := range cl.Nodes {
+		if err := node.Terminate(ctx); err != nil {
+			return fmt.Errorf("failed to terminate node %d: %w", i, err)
+		}
+	}
+
+	if cl.network != nil {
+		if err := cl.network.Remove(ctx); err != nil {
+			return fmt.Errorf("failed to remove cluster network: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// waitUntilHealthy polls every node's Admin API health-check endpoint, the
+// same readiness gate StartContainer() waits on for a single node.
+func (cl *Cluster) waitUntilHealthy(ctx context.Context) error {
+	for i, node := range cl.Nodes {
+		var tlsConfig *tls.Config
+		if node.tlsConfig != nil {
+			var err error
+			if tlsConfig, err = node.TLSConfig(); err != nil {
+				return fmt.Errorf("node %d: %w", i, err)
+			}
+		}
+
+		err := wait.ForHTTP("/v1/cluster/health_overview").
+			WithPort(nat.Port(defaultAdminAPIPort)).
+			WithTLS(tlsConfig != nil, tlsConfig).
+			WithResponseMatcher(clusterIsHealthy).
+			WithPollInterval(100*time.Millisecond).
+			WaitUntilReady(ctx, node)
+		if err != nil {
+			return fmt.Errorf("node %d did not become healthy: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package redpanda_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/redpanda"
+)
+
+func TestStartCluster(t *testing.T) {
+	ctx := context.Background()
+
+	cluster, err := redpanda.StartCluster(ctx, 3)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cluster.Terminate(ctx)) })
+
+	require.Len(t, cluster.Nodes, 3)
+
+	brokers, err := cluster.KafkaSeedBrokers(ctx)
+	require.NoError(t, err)
+	require.Len(t, strings.Split(brokers, ","), 3)
+}
+
+// TestStartClusterBootstrapsOnce guards against the service account/topic
+// bootstrap running once per node: if it did, every node after the first
+// would fail with an "already exists" error once it joined the cluster.
+func TestStartClusterBootstrapsOnce(t *testing.T) {
+	ctx := context.Background()
+
+	cluster, err := redpanda.StartCluster(
+		ctx, 3,
+		redpanda.WithNewServiceAccount("superuser-1", "test"),
+		redpanda.WithAutoCreateTopics(redpanda.TopicSpec{Name: "cluster-topic", Partitions: 3, ReplicationFactor: 3}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cluster.Terminate(ctx)) })
+
+	require.Len(t, cluster.Nodes, 3)
+}
+
+// TestClusterRolling restarts every node one at a time via Rolling and
+// confirms the cluster comes back healthy and reachable after each restart.
+func TestClusterRolling(t *testing.T) {
+	ctx := context.Background()
+
+	cluster, err := redpanda.StartCluster(ctx, 3)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cluster.Terminate(ctx)) })
+
+	var restarted []int
+	err = cluster.Rolling(ctx, func(ctx context.Context, node *redpanda.Container) error {
+		restarted = append(restarted, len(restarted))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, restarted, 3)
+
+	brokers, err := cluster.KafkaSeedBrokers(ctx)
+	require.NoError(t, err)
+	require.Len(t, strings.Split(brokers, ","), 3)
+}
@@ -1,6 +1,23 @@
 package redpanda
 
-import "github.com/testcontainers/testcontainers-go"
+// defaultKafkaListenerName is the name of the Kafka API listener that is
+// always present, even if the caller never calls WithListener().
+const defaultKafkaListenerName = "kafka"
+
+// ListenerConfig configures a single named Kafka API listener. Each listener
+// is exposed on its own port and can be configured with its own
+// authentication method, mirroring Redpanda's NamedAuthNSocketAddress model.
+type ListenerConfig struct {
+	// Name is the listener name as it'll show up in the kafka_api /
+	// advertised_kafka_api blocks of redpanda.yaml.
+	Name string
+
+	// Port is the container port this listener binds to.
+	Port int
+
+	// AuthenticationMethod is one of "none", "sasl" or "mtls_identity".
+	AuthenticationMethod string
+}
 
 type options struct {
 	// Superusers is a list of service account names.
@@ -9,29 +26,66 @@ type options struct {
 	// KafkaEnableAuthorization is a flag to require authorization for Kafka connections.
 	KafkaEnableAuthorization bool
 
-	// KafkaAuthenticationMethod is either "none" for plaintext or "sasl"
-	// for SASL (scram) authentication.
-	KafkaAuthenticationMethod string
+	// Listeners holds the configured Kafka API listeners. There is always at
+	// least the default "kafka" listener on port 9092; WithListener() appends
+	// additional named listeners.
+	Listeners []ListenerConfig
 
 	// SchemaRegistryAuthenticationMethod is either "none" for no authentication
 	// or "http_basic" for HTTP basic authentication.
 	SchemaRegistryAuthenticationMethod string
 
+	// SchemaRegistryUsername is the service account (configured via
+	// WithNewServiceAccount()) that Container.RegisterSchema() authenticates
+	// with, when SchemaRegistryAuthenticationMethod is "http_basic". Set via
+	// WithEnableSchemaRegistryHTTPBasicAuth().
+	SchemaRegistryUsername string
+
+	// EnableHTTPProxy determines whether the Pandaproxy (HTTP Proxy) listener
+	// is enabled and exposed.
+	EnableHTTPProxy bool
+
+	// HTTPProxyAuthenticationMethod is either "none" for no authentication
+	// or "http_basic" for HTTP basic authentication.
+	HTTPProxyAuthenticationMethod string
+
 	// ServiceAccounts is a map of username (key) to password (value) of users
 	// that shall be created, so that you can use these to authenticate against
 	// Redpanda (either for the Kafka API or Schema Registry HTTP access).
 	ServiceAccounts map[string]string
 
+	// TLSConfig holds the certificate material and mTLS settings configured
+	// via WithTLS()/WithMTLS(). It's nil unless WithTLS() or WithMTLS() has
+	// been called.
+	TLSConfig *TLSConfig
+
+	// AutoCreateTopics holds the topics configured via WithAutoCreateTopics(),
+	// created via the Admin API once the cluster reports healthy.
+	AutoCreateTopics []TopicSpec
+
+	// PersistentVolumeHostPath is the host directory bind-mounted to
+	// Redpanda's data directory, or empty for an ephemeral data directory.
+	// See WithPersistentVolume().
+	PersistentVolumeHostPath string
+
+	// cluster holds the Docker network wiring for a node that's part of a
+	// multi-node Cluster. It's nil for a standalone StartContainer() node.
+	cluster *clusterNodeOptions
+
 	// Docker image and version
 	Image string
 }
 
 func defaultOptions() options {
 	return options{
-		KafkaEnableAuthorization:           false,
-		Superusers:                         []string{},
-		KafkaAuthenticationMethod:          "none",
+		KafkaEnableAuthorization: false,
+		Superusers:               []string{},
+		Listeners: []ListenerConfig{
+			{Name: defaultKafkaListenerName, Port: 9092, AuthenticationMethod: "none"},
+		},
 		SchemaRegistryAuthenticationMethod: "none",
+		EnableHTTPProxy:                    false,
+		HTTPProxyAuthenticationMethod:      "none",
 		ServiceAccounts:                    make(map[string]string, 0),
 		Image:                              "docker.redpanda.com/redpandadata/redpanda:v23.1.6",
 	}
@@ -40,7 +94,7 @@ func defaultOptions() options {
 // Option is an option for the Redpanda container.
 type Option func(*options)
 
-func WithNewServiceAccount(username, password string) testcontainers.CustomizeRequestOption {
+func WithNewServiceAccount(username, password string) Option {
 	return func(o *options) {
 		o.ServiceAccounts[username] = password
 	}
@@ -48,34 +102,87 @@ func WithNewServiceAccount(username, password string) testcontainers.CustomizeRe
 
 // WithSuperusers defines the superusers added to the redpanda config.
 // By default, there are no superusers.
-func WithSuperusers(superusers ...string) testcontainers.CustomizeRequestOption {
+func WithSuperusers(superusers ...string) Option {
 	return func(o *options) {
 		o.Superusers = superusers
 	}
 }
 
-// WithEnableSASL enables SASL scram sha authentication.
-// By default, no authentication (plaintext) is used.
+// WithEnableSASL enables SASL scram sha authentication on the default
+// "kafka" listener. By default, no authentication (plaintext) is used.
 // When setting an authentication method, make sure to add users
 // as well as authorize them using the WithSuperusers() option.
-func WithEnableSASL() testcontainers.CustomizeRequestOption {
+//
+// This is sugar on top of WithListener(): it's equivalent to calling
+// WithListener(defaultKafkaListenerName, 9092, "sasl").
+func WithEnableSASL() Option {
+	return WithListener(defaultKafkaListenerName, 9092, "sasl")
+}
+
+// WithListener configures a named Kafka API listener with its own
+// authentication method ("none", "sasl" or "mtls_identity"), exposing it on
+// the given port. Calling it with the default listener's name
+// (defaultKafkaListenerName, "kafka") replaces that listener's settings
+// instead of adding a duplicate one.
+func WithListener(name string, port int, authN string) Option {
 	return func(o *options) {
-		o.KafkaAuthenticationMethod = "sasl"
+		for i, l := range o.Listeners {
+			if l.Name == name {
+				o.Listeners[i].Port = port
+				o.Listeners[i].AuthenticationMethod = authN
+				return
+			}
+		}
+		o.Listeners = append(o.Listeners, ListenerConfig{Name: name, Port: port, AuthenticationMethod: authN})
 	}
 }
 
 // WithEnableKafkaAuthorization enables authorization for connections on the Kafka API.
-func WithEnableKafkaAuthorization() testcontainers.CustomizeRequestOption {
+func WithEnableKafkaAuthorization() Option {
 	return func(o *options) {
 		o.KafkaEnableAuthorization = true
 	}
 }
 
-func WithEnableSchemaRegistryHTTPBasicAuth() testcontainers.CustomizeRequestOption {
-	return func(req *testcontainers.GenericContainerRequest) {
-		req.
-	}
+// WithEnableSchemaRegistryHTTPBasicAuth enables HTTP basic authentication for
+// the Schema Registry, authenticating Container.RegisterSchema() as the
+// given service account. username must have been registered via a prior
+// WithNewServiceAccount() call.
+func WithEnableSchemaRegistryHTTPBasicAuth(username string) Option {
 	return func(o *options) {
 		o.SchemaRegistryAuthenticationMethod = "http_basic"
+		o.SchemaRegistryUsername = username
+	}
+}
+
+// WithEnableHTTPProxy enables the Pandaproxy (HTTP Proxy) listener, which
+// lets clients produce/consume via HTTP, and exposes its port.
+// Authentication for the HTTP Proxy follows the same service accounts as
+// the Kafka API / Schema Registry. Use WithEnableHTTPProxyHTTPBasicAuth()
+// to require HTTP basic authentication for it.
+func WithEnableHTTPProxy() Option {
+	return func(o *options) {
+		o.EnableHTTPProxy = true
+	}
+}
+
+// WithEnableHTTPProxyHTTPBasicAuth enables HTTP basic authentication for the
+// Pandaproxy (HTTP Proxy) listener. This implicitly enables the HTTP Proxy
+// listener as well.
+func WithEnableHTTPProxyHTTPBasicAuth() Option {
+	return func(o *options) {
+		o.EnableHTTPProxy = true
+		o.HTTPProxyAuthenticationMethod = "http_basic"
+	}
+}
+
+// WithPersistentVolume bind-mounts hostPath to Redpanda's data directory
+// (/var/lib/redpanda/data), so data survives a Container.Restart(). If
+// hostPath already contains data from a previous run, the bootstrap config -
+// which Redpanda only honors on a cluster's first boot - is not mounted
+// again.
+func WithPersistentVolume(hostPath string) Option {
+	return func(o *options) {
+		o.PersistentVolumeHostPath = hostPath
 	}
 }
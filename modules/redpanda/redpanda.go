@@ -3,6 +3,8 @@ package redpanda
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	_ "embed"
 	"encoding/json"
 	"fmt"
@@ -28,14 +30,37 @@ var (
 	//go:embed mounts/entrypoint-tc.sh
 	entrypoint []byte
 
-	defaultKafkaAPIPort       = "9092/tcp"
 	defaultAdminAPIPort       = "9644/tcp"
 	defaultSchemaRegistryPort = "8081/tcp"
+	defaultHTTPProxyPort      = "8082/tcp"
+)
+
+const (
+	certsDir       = "/etc/redpanda/certs"
+	certFile       = certsDir + "/node.crt"
+	keyFile        = certsDir + "/node.key"
+	truststoreFile = certsDir + "/ca.crt"
 )
 
 // Container represents the redpanda container type used in the module
 type Container struct {
 	testcontainers.Container
+
+	// kafkaListenerPorts maps each configured Kafka API listener name to its
+	// container port, so accessors can look up the right mapped port.
+	kafkaListenerPorts map[string]nat.Port
+
+	// tlsConfig holds the certificate material configured via WithTLS(), or
+	// nil if TLS was not enabled. It's used to pick the right URL scheme in
+	// the HTTP-based accessors and to build a client *tls.Config.
+	tlsConfig *TLSConfig
+
+	// schemaRegistryUsername/schemaRegistryPassword are the credentials
+	// RegisterSchema() authenticates with, set from the service account
+	// named via WithEnableSchemaRegistryHTTPBasicAuth(). Empty if HTTP basic
+	// auth isn't in use.
+	schemaRegistryUsername string
+	schemaRegistryPassword string
 }
 
 // StartContainer creates an instance of the redpanda container.
@@ -66,27 +91,43 @@ func StartContainer(ctx context.Context, opts ...Option) (*Container, error) {
 	}
 
 	// 3. Create container request and start container
-	containerReq := testcontainers.ContainerRequest{
-		Image: settings.Image,
-		User:  "root:root",
-		Files: []testcontainers.ContainerFile{
-			{
-				HostFilePath:      entrypointFile.Name(),
-				ContainerFilePath: "/entrypoint-tc.sh",
-				FileMode:          700,
-			},
-			{
-				HostFilePath:      bootstrapConfigFile.Name(),
-				ContainerFilePath: "/etc/redpanda/.bootstrap.yaml",
-				FileMode:          700,
-			},
-		},
-		ExposedPorts: []string{
-			defaultKafkaAPIPort,
-			defaultAdminAPIPort,
-			defaultSchemaRegistryPort,
+	exposedPorts := []string{
+		defaultAdminAPIPort,
+		defaultSchemaRegistryPort,
+	}
+	for _, listener := range settings.Listeners {
+		exposedPorts = append(exposedPorts, string(listenerPort(listener)))
+	}
+	if settings.EnableHTTPProxy {
+		exposedPorts = append(exposedPorts, defaultHTTPProxyPort)
+	}
+
+	// A persistent volume that already has data from a previous run means the
+	// cluster has already completed its first boot, so the bootstrap config
+	// (only honored on first boot) doesn't need to be mounted again.
+	persistentDataExists := settings.PersistentVolumeHostPath != "" && hasExistingData(settings.PersistentVolumeHostPath)
+
+	files := []testcontainers.ContainerFile{
+		{
+			HostFilePath:      entrypointFile.Name(),
+			ContainerFilePath: "/entrypoint-tc.sh",
+			FileMode:          700,
 		},
-		Entrypoint: []string{},
+	}
+	if !persistentDataExists {
+		files = append(files, testcontainers.ContainerFile{
+			HostFilePath:      bootstrapConfigFile.Name(),
+			ContainerFilePath: "/etc/redpanda/.bootstrap.yaml",
+			FileMode:          700,
+		})
+	}
+
+	containerReq := testcontainers.ContainerRequest{
+		Image:        settings.Image,
+		User:         "root:root",
+		Files:        files,
+		ExposedPorts: exposedPorts,
+		Entrypoint:   []string{},
 		Cmd: []string{
 			"/entrypoint-tc.sh",
 			"redpanda",
@@ -95,6 +136,20 @@ func StartContainer(ctx context.Context, opts ...Option) (*Container, error) {
 		},
 	}
 
+	if settings.PersistentVolumeHostPath != "" {
+		containerReq.Mounts = testcontainers.ContainerMounts{
+			testcontainers.BindMount(settings.PersistentVolumeHostPath, "/var/lib/redpanda/data"),
+		}
+	}
+
+	if settings.cluster != nil {
+		containerReq.Networks = []string{settings.cluster.networkName}
+		containerReq.NetworkAliases = map[string][]string{
+			settings.cluster.networkName: {settings.cluster.alias},
+		}
+		containerReq.Hostname = settings.cluster.alias
+	}
+
 	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: containerReq,
 		Started:          true,
@@ -110,13 +165,39 @@ func StartContainer(ctx context.Context, opts ...Option) (*Container, error) {
 		return nil, fmt.Errorf("failed to get container host: %w", err)
 	}
 
-	kafkaPort, err := container.MappedPort(ctx, nat.Port(defaultKafkaAPIPort))
-	if err != nil {
-		return nil, fmt.Errorf("failed to get mapped Kafka port: %w", err)
+	// Every Kafka API listener needs its own mapped port, because each one is
+	// rendered into its own advertised_kafka_api entry.
+	listenerMappedPorts := make(map[string]int, len(settings.Listeners))
+	for _, listener := range settings.Listeners {
+		mappedPort, err := container.MappedPort(ctx, listenerPort(listener))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get mapped port for listener %q: %w", listener.Name, err)
+		}
+		listenerMappedPorts[listener.Name] = mappedPort.Int()
+	}
+
+	// Get mapped port for the HTTP Proxy (Pandaproxy) as well, so its
+	// advertised address can be rendered using the same two-step entrypoint
+	// trick as the Kafka API.
+	var httpProxyPort int
+	if settings.EnableHTTPProxy {
+		mappedHTTPProxyPort, err := container.MappedPort(ctx, nat.Port(defaultHTTPProxyPort))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get mapped HTTP Proxy port: %w", err)
+		}
+		httpProxyPort = mappedHTTPProxyPort.Int()
+	}
+
+	// Provision the TLS certificate material, if configured, before it's
+	// referenced by the node config rendered below.
+	if settings.TLSConfig != nil {
+		if err := copyTLSCertificates(ctx, container, settings.TLSConfig); err != nil {
+			return nil, fmt.Errorf("failed to copy TLS certificates into container: %w", err)
+		}
 	}
 
 	// 5. Render redpanda.yaml config and mount it.
-	nodeConfig, err := renderNodeConfig(settings, hostIP, kafkaPort.Int())
+	nodeConfig, err := renderNodeConfig(settings, hostIP, listenerMappedPorts, httpProxyPort)
 	if err != nil {
 		return nil, fmt.Errorf("failed to render node config: %w", err)
 	}
@@ -131,41 +212,46 @@ func StartContainer(ctx context.Context, opts ...Option) (*Container, error) {
 		return nil, fmt.Errorf("failed to copy redpanda.yaml into container: %w", err)
 	}
 
+	// The admin API listener is TLS-enabled whenever WithTLS() is used (there's
+	// no per-listener opt-out for it, unlike the Kafka API), so the readiness
+	// check and the admin client below need a matching scheme and trust root.
+	var clientTLSConfig *tls.Config
+	if settings.TLSConfig != nil {
+		clientTLSConfig, err = buildClientTLSConfig(settings.TLSConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// 6. Wait until Redpanda is ready to serve requests
 	err = wait.ForAll(
 		wait.ForLog("Successfully started Redpanda!").WithPollInterval(100*time.Millisecond),
 		wait.ForHTTP("/v1/cluster/health_overview").
 			WithPort(nat.Port(defaultAdminAPIPort)).
-			WithResponseMatcher(func(body io.Reader) bool {
-				response, err := io.ReadAll(body)
-				if err != nil {
-					return false
-				}
-
-				healthOverview := admin.ClusterHealthOverview{}
-				if err := json.Unmarshal(response, &healthOverview); err != nil {
-					return false
-				}
-
-				return healthOverview.IsHealthy
-			}).
+			WithTLS(clientTLSConfig != nil, clientTLSConfig).
+			WithResponseMatcher(clusterIsHealthy).
 			WithPollInterval(100*time.Millisecond),
 	).WaitUntilReady(ctx, container)
 	if err != nil {
 		return nil, fmt.Errorf("failed to wait for Redpanda readiness: %w", err)
 	}
 
-	// 7. Create Redpanda Service Accounts if configured to do so.
-	if len(settings.ServiceAccounts) > 0 {
+	// 7. Create Redpanda Service Accounts and topics if configured to do so.
+	// Service accounts/ACLs/topics are cluster-wide, replicated state, so for
+	// a multi-node Cluster this must only run once - against the seed node -
+	// instead of once per node, or every node after the first would fail
+	// with an "already exists" error from the admin API once it has joined
+	// the cluster.
+	isBootstrapNode := settings.cluster == nil || settings.cluster.nodeID == 0
+	if isBootstrapNode && (len(settings.ServiceAccounts) > 0 || len(settings.AutoCreateTopics) > 0) {
 		adminAPIPort, err := container.MappedPort(ctx, nat.Port(defaultAdminAPIPort))
 		if err != nil {
 			return nil, fmt.Errorf("failed to get mapped Admin API port: %w", err)
 		}
 
-		adminAPIUrl := fmt.Sprintf("http://%v:%d", hostIP, adminAPIPort.Int())
-		adminCl, err := admin.NewAdminAPI([]string{adminAPIUrl}, admin.BasicCredentials{}, nil)
+		adminCl, err := adminAPIClient(hostIP, adminAPIPort.Int(), clientTLSConfig)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create new admin api client: %w", err)
+			return nil, err
 		}
 
 		for username, password := range settings.ServiceAccounts {
@@ -173,36 +259,160 @@ func StartContainer(ctx context.Context, opts ...Option) (*Container, error) {
 				return nil, fmt.Errorf("failed to create service account with username %q: %w", username, err)
 			}
 		}
+
+		if err := createTopics(ctx, adminCl, settings.AutoCreateTopics); err != nil {
+			return nil, err
+		}
 	}
 
-	return &Container{Container: container}, nil
+	kafkaListenerPorts := make(map[string]nat.Port, len(settings.Listeners))
+	for _, listener := range settings.Listeners {
+		kafkaListenerPorts[listener.Name] = listenerPort(listener)
+	}
+
+	var schemaRegistryUsername, schemaRegistryPassword string
+	if settings.SchemaRegistryAuthenticationMethod == "http_basic" {
+		schemaRegistryUsername = settings.SchemaRegistryUsername
+		schemaRegistryPassword = settings.ServiceAccounts[schemaRegistryUsername]
+	}
+
+	return &Container{
+		Container:              container,
+		kafkaListenerPorts:     kafkaListenerPorts,
+		tlsConfig:              settings.TLSConfig,
+		schemaRegistryUsername: schemaRegistryUsername,
+		schemaRegistryPassword: schemaRegistryPassword,
+	}, nil
 }
 
 // KafkaSeedBroker returns the seed broker that should be used for connecting
 // to the Kafka API with your Kafka client. It'll be returned in the format:
-// "host:port" - for example: "localhost:55687".
+// "host:port" - for example: "localhost:55687". It always refers to the
+// default "kafka" listener; use KafkaSeedBrokerForListener() for any
+// additional listeners configured via WithListener().
 func (c *Container) KafkaSeedBroker(ctx context.Context) (string, error) {
-	return c.getMappedHostPort(ctx, nat.Port(defaultKafkaAPIPort))
+	return c.KafkaSeedBrokerForListener(ctx, defaultKafkaListenerName)
+}
+
+// KafkaSeedBrokerForListener returns the seed broker for a specific named
+// Kafka API listener, in the same "host:port" format as KafkaSeedBroker().
+func (c *Container) KafkaSeedBrokerForListener(ctx context.Context, listenerName string) (string, error) {
+	port, ok := c.kafkaListenerPorts[listenerName]
+	if !ok {
+		return "", fmt.Errorf("no listener named %q was configured", listenerName)
+	}
+	return c.getMappedHostPort(ctx, port)
 }
 
 // AdminAPIAddress returns the address to the Redpanda Admin API. This
-// is an HTTP-based API and thus the returned format will be: http://host:port.
+// is an HTTP-based API and thus the returned format will be: http://host:port,
+// or https://host:port if the container was started with WithTLS().
 func (c *Container) AdminAPIAddress(ctx context.Context) (string, error) {
 	hostPort, err := c.getMappedHostPort(ctx, nat.Port(defaultAdminAPIPort))
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("http://%v", hostPort), nil
+	return fmt.Sprintf("%v://%v", c.httpScheme(), hostPort), nil
 }
 
 // SchemaRegistryAddress returns the address to the schema registry API. This
-// is an HTTP-based API and thus the returned format will be: http://host:port.
+// is an HTTP-based API and thus the returned format will be: http://host:port,
+// or https://host:port if the container was started with WithTLS().
 func (c *Container) SchemaRegistryAddress(ctx context.Context) (string, error) {
 	hostPort, err := c.getMappedHostPort(ctx, nat.Port(defaultSchemaRegistryPort))
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("http://%v", hostPort), nil
+	return fmt.Sprintf("%v://%v", c.httpScheme(), hostPort), nil
+}
+
+// HTTPProxyAddress returns the address to the Pandaproxy (HTTP Proxy). This
+// is an HTTP-based API and thus the returned format will be: http://host:port,
+// or https://host:port if the container was started with WithTLS().
+// The HTTP Proxy is only reachable if the container was started with the
+// WithEnableHTTPProxy() option.
+func (c *Container) HTTPProxyAddress(ctx context.Context) (string, error) {
+	hostPort, err := c.getMappedHostPort(ctx, nat.Port(defaultHTTPProxyPort))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v://%v", c.httpScheme(), hostPort), nil
+}
+
+// httpScheme returns "https" if the container was started with WithTLS(),
+// and "http" otherwise.
+func (c *Container) httpScheme() string {
+	if c.tlsConfig != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// TLSConfig returns a *tls.Config suitable for a Kafka client (e.g.
+// franz-go or sarama) to connect to a TLS-enabled listener, trusting the
+// node certificate configured via WithTLS(). It returns an error if the
+// container wasn't started with WithTLS().
+func (c *Container) TLSConfig() (*tls.Config, error) {
+	if c.tlsConfig == nil {
+		return nil, fmt.Errorf("container was not started with TLS enabled, see WithTLS()")
+	}
+	return buildClientTLSConfig(c.tlsConfig)
+}
+
+// buildClientTLSConfig returns a *tls.Config trusting tlsConfig's node
+// certificate, shared by Container.TLSConfig() and the internal admin API /
+// Schema Registry HTTP clients used during bootstrap.
+func buildClientTLSConfig(tlsConfig *TLSConfig) (*tls.Config, error) {
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(tlsConfig.CertPEM) {
+		return nil, fmt.Errorf("failed to parse node certificate PEM")
+	}
+
+	return &tls.Config{RootCAs: certPool}, nil
+}
+
+// clusterIsHealthy is the wait.ForHTTP response matcher for the Admin API's
+// cluster health-check endpoint, shared by StartContainer() and Cluster's
+// multi-node readiness gate.
+func clusterIsHealthy(body io.Reader) bool {
+	response, err := io.ReadAll(body)
+	if err != nil {
+		return false
+	}
+
+	healthOverview := admin.ClusterHealthOverview{}
+	if err := json.Unmarshal(response, &healthOverview); err != nil {
+		return false
+	}
+
+	return healthOverview.IsHealthy
+}
+
+// Restart stops and starts the container, preserving the data directory when
+// the container was started with WithPersistentVolume(). This is useful for
+// testing offset/consumer-group persistence and controller log recovery
+// across a restart.
+func (c *Container) Restart(ctx context.Context) error {
+	if err := c.Stop(ctx, nil); err != nil {
+		return fmt.Errorf("failed to stop container: %w", err)
+	}
+
+	if err := c.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+
+	return nil
+}
+
+// hasExistingData reports whether hostPath already contains files from a
+// previous run, used to decide whether the bootstrap config needs to be
+// mounted again on this start.
+func hasExistingData(hostPath string) bool {
+	entries, err := os.ReadDir(hostPath)
+	if err != nil {
+		return false
+	}
+	return len(entries) > 0
 }
 
 // getMappedHostPort returns the mapped host and port a given nat.Port following
@@ -246,6 +456,9 @@ func createBootstrapConfigFile(settings options) (*os.File, error) {
 		Superusers:                  settings.Superusers,
 		KafkaAPIEnableAuthorization: settings.KafkaEnableAuthorization,
 	}
+	if settings.usesMTLSIdentity() {
+		bootstrapTplParams.KafkaMTLSPrincipalMappingRules = []string{defaultMTLSPrincipalMappingRule}
+	}
 
 	tpl, err := template.New("bootstrap.yaml").Parse(bootstrapConfigTpl)
 	if err != nil {
@@ -269,19 +482,85 @@ func createBootstrapConfigFile(settings options) (*os.File, error) {
 	return bootstrapTmpFile, nil
 }
 
+// copyTLSCertificates copies the node certificate/key and (if mTLS is
+// enabled) the CA certificate into the container, so they can be referenced
+// by file path from redpanda.yaml.
+func copyTLSCertificates(ctx context.Context, container testcontainers.Container, tlsConfig *TLSConfig) error {
+	if err := container.CopyToContainer(ctx, tlsConfig.CertPEM, certFile, 644); err != nil {
+		return fmt.Errorf("failed to copy node certificate: %w", err)
+	}
+
+	if err := container.CopyToContainer(ctx, tlsConfig.KeyPEM, keyFile, 600); err != nil {
+		return fmt.Errorf("failed to copy node key: %w", err)
+	}
+
+	if tlsConfig.MTLSEnabled {
+		if err := container.CopyToContainer(ctx, tlsConfig.MTLSCAPEM, truststoreFile, 644); err != nil {
+			return fmt.Errorf("failed to copy CA certificate: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// listenerPort returns the nat.Port a given Kafka API listener is exposed on.
+func listenerPort(l ListenerConfig) nat.Port {
+	return nat.Port(fmt.Sprintf("%d/tcp", l.Port))
+}
+
 // renderNodeConfig renders the redpanda.yaml node config and retuns it as
 // byte array.
-func renderNodeConfig(settings options, hostIP string, advertisedKafkaPort int) ([]byte, error) {
+func renderNodeConfig(settings options, hostIP string, listenerMappedPorts map[string]int, advertisedHTTPProxyPort int) ([]byte, error) {
+	listeners := make([]redpandaConfigTplParamsKafkaAPIListener, 0, len(settings.Listeners))
+	for _, listener := range settings.Listeners {
+		listeners = append(listeners, redpandaConfigTplParamsKafkaAPIListener{
+			Name:                 listener.Name,
+			Port:                 listener.Port,
+			AdvertisedHost:       hostIP,
+			AdvertisedPort:       listenerMappedPorts[listener.Name],
+			AuthenticationMethod: listener.AuthenticationMethod,
+			TLSEnabled:           settings.TLSConfig.usesTLS(listener.Name),
+		})
+	}
+
 	tplParams := redpandaConfigTplParams{
 		KafkaAPI: redpandaConfigTplParamsKafkaAPI{
-			AdvertisedHost:       hostIP,
-			AdvertisedPort:       advertisedKafkaPort,
-			AuthenticationMethod: settings.KafkaAuthenticationMethod,
-			EnableAuthorization:  settings.KafkaEnableAuthorization,
+			Listeners:           listeners,
+			EnableAuthorization: settings.KafkaEnableAuthorization,
 		},
 		SchemaRegistry: redpandaConfigTplParamsSchemaRegistry{
 			AuthenticationMethod: settings.SchemaRegistryAuthenticationMethod,
 		},
+		HTTPProxy: redpandaConfigTplParamsHTTPProxy{
+			Enabled:              settings.EnableHTTPProxy,
+			AdvertisedHost:       hostIP,
+			AdvertisedPort:       advertisedHTTPProxyPort,
+			AuthenticationMethod: settings.HTTPProxyAuthenticationMethod,
+		},
+		TLS: redpandaConfigTplParamsTLS{
+			Enabled:  settings.TLSConfig != nil,
+			CertFile: certFile,
+			KeyFile:  keyFile,
+		},
+		Node: redpandaConfigTplParamsNode{
+			ID: -1,
+		},
+	}
+
+	if settings.TLSConfig != nil {
+		tplParams.TLS.MTLSEnabled = settings.TLSConfig.MTLSEnabled
+		tplParams.TLS.MTLSRequireClientAuth = settings.TLSConfig.MTLSRequireClientAuth
+		tplParams.TLS.TruststoreFile = truststoreFile
+	}
+
+	if settings.cluster != nil {
+		tplParams.Node.ID = settings.cluster.nodeID
+		for _, seed := range settings.cluster.seedServers {
+			tplParams.Node.SeedServers = append(tplParams.Node.SeedServers, redpandaConfigTplParamsSeedServer{
+				Address: seed.alias,
+				Port:    clusterRPCPort,
+			})
+		}
 	}
 
 	ncTpl, err := template.New("redpanda.yaml").Parse(nodeConfigTpl)
@@ -298,22 +577,61 @@ func renderNodeConfig(settings options, hostIP string, advertisedKafkaPort int)
 }
 
 type redpandaBootstrapConfigTplParams struct {
-	Superusers                  []string
-	KafkaAPIEnableAuthorization bool
+	Superusers                     []string
+	KafkaAPIEnableAuthorization    bool
+	KafkaMTLSPrincipalMappingRules []string
 }
 
 type redpandaConfigTplParams struct {
 	KafkaAPI       redpandaConfigTplParamsKafkaAPI
 	SchemaRegistry redpandaConfigTplParamsSchemaRegistry
+	HTTPProxy      redpandaConfigTplParamsHTTPProxy
+	TLS            redpandaConfigTplParamsTLS
+	Node           redpandaConfigTplParamsNode
+}
+
+// redpandaConfigTplParamsNode carries the settings that only apply to a node
+// that's part of a multi-node Cluster. ID is -1 for a standalone container.
+type redpandaConfigTplParamsNode struct {
+	ID          int
+	SeedServers []redpandaConfigTplParamsSeedServer
+}
+
+type redpandaConfigTplParamsSeedServer struct {
+	Address string
+	Port    int
 }
 
 type redpandaConfigTplParamsKafkaAPI struct {
+	Listeners           []redpandaConfigTplParamsKafkaAPIListener
+	EnableAuthorization bool
+}
+
+type redpandaConfigTplParamsKafkaAPIListener struct {
+	Name                 string
+	Port                 int
 	AdvertisedHost       string
 	AdvertisedPort       int
 	AuthenticationMethod string
-	EnableAuthorization  bool
+	TLSEnabled           bool
+}
+
+type redpandaConfigTplParamsTLS struct {
+	Enabled               bool
+	CertFile              string
+	KeyFile               string
+	MTLSEnabled           bool
+	MTLSRequireClientAuth bool
+	TruststoreFile        string
 }
 
 type redpandaConfigTplParamsSchemaRegistry struct {
 	AuthenticationMethod string
 }
+
+type redpandaConfigTplParamsHTTPProxy struct {
+	Enabled              bool
+	AdvertisedHost       string
+	AdvertisedPort       int
+	AuthenticationMethod string
+}
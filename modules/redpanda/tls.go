@@ -0,0 +1,106 @@
+package redpanda
+
+// defaultMTLSPrincipalMappingRule extracts the certificate's CN as the
+// authenticated principal. It mirrors the default rule `rpk` configures when
+// bootstrapping a cluster with mTLS identity authentication.
+const defaultMTLSPrincipalMappingRule = "CN=(.*?),(?:.*)"
+
+// TLSConfig holds the certificate material and mTLS settings provisioned via
+// WithTLS() and WithMTLS(). Its zero value (as returned by a fresh *TLSConfig)
+// represents "TLS configured, mTLS not required".
+type TLSConfig struct {
+	// CertPEM and KeyPEM are the node certificate and private key, PEM
+	// encoded, that Redpanda will present on its TLS listeners.
+	CertPEM []byte
+	KeyPEM  []byte
+
+	// Listeners restricts which named Kafka API listeners get the
+	// kafka_api_tls block. An empty slice means "all configured listeners".
+	Listeners []string
+
+	// MTLSEnabled indicates whether WithMTLS() has been called.
+	MTLSEnabled bool
+
+	// MTLSCAPEM is the CA certificate, PEM encoded, used to verify client
+	// certificates when MTLSEnabled is true.
+	MTLSCAPEM []byte
+
+	// MTLSRequireClientAuth determines whether a client certificate is
+	// mandatory (true) or merely verified if presented (false).
+	MTLSRequireClientAuth bool
+}
+
+// TLSOption further customizes the TLS configuration provisioned via
+// WithTLS().
+type TLSOption func(*TLSConfig)
+
+// WithTLSListeners restricts which named Kafka API listeners (configured via
+// WithListener()) get TLS. By default, TLS is applied to every listener.
+func WithTLSListeners(listenerNames ...string) TLSOption {
+	return func(c *TLSConfig) {
+		c.Listeners = listenerNames
+	}
+}
+
+// WithTLS provisions certPEM/keyPEM into the container (under
+// /etc/redpanda/certs/) and enables TLS on the Kafka API, Admin API, Schema
+// Registry and Pandaproxy listeners.
+func WithTLS(certPEM, keyPEM []byte, opts ...TLSOption) Option {
+	return func(o *options) {
+		if o.TLSConfig == nil {
+			o.TLSConfig = &TLSConfig{}
+		}
+		o.TLSConfig.CertPEM = certPEM
+		o.TLSConfig.KeyPEM = keyPEM
+		for _, opt := range opts {
+			opt(o.TLSConfig)
+		}
+	}
+}
+
+// WithMTLS enables mutual TLS on top of WithTLS(), verifying client
+// certificates against caPEM. requireClientAuth controls whether presenting
+// a client certificate is mandatory. WithTLS() must also be configured,
+// since a node certificate is required to terminate TLS in the first place.
+//
+// To authenticate clients by the identity embedded in their certificate,
+// configure a listener with WithListener(name, port, "mtls_identity").
+func WithMTLS(caPEM []byte, requireClientAuth bool) Option {
+	return func(o *options) {
+		if o.TLSConfig == nil {
+			o.TLSConfig = &TLSConfig{}
+		}
+		o.TLSConfig.MTLSEnabled = true
+		o.TLSConfig.MTLSCAPEM = caPEM
+		o.TLSConfig.MTLSRequireClientAuth = requireClientAuth
+	}
+}
+
+// usesTLS reports whether a given Kafka API listener name should have TLS
+// enabled, honoring the (optional) WithTLSListeners() restriction.
+func (c *TLSConfig) usesTLS(listenerName string) bool {
+	if c == nil {
+		return false
+	}
+	if len(c.Listeners) == 0 {
+		return true
+	}
+	for _, name := range c.Listeners {
+		if name == listenerName {
+			return true
+		}
+	}
+	return false
+}
+
+// usesMTLSIdentity reports whether any configured Kafka API listener
+// authenticates via the "mtls_identity" method, which requires the bootstrap
+// config to set kafka_mtls_principal_mapping_rules.
+func (o *options) usesMTLSIdentity() bool {
+	for _, l := range o.Listeners {
+		if l.AuthenticationMethod == "mtls_identity" {
+			return true
+		}
+	}
+	return false
+}
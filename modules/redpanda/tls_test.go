@@ -0,0 +1,100 @@
+package redpanda_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/testcontainers/testcontainers-go/modules/redpanda"
+)
+
+// generateSelfSignedCert returns a PEM-encoded certificate/key pair valid for
+// "localhost" and 127.0.0.1, the host testcontainers-go reports by default.
+func generateSelfSignedCert(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	require.NoError(t, err)
+
+	keyBytes, err := x509.MarshalECPrivateKey(priv)
+	require.NoError(t, err)
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+	return certPEM, keyPEM
+}
+
+func TestRedpandaWithTLS(t *testing.T) {
+	ctx := context.Background()
+
+	certPEM, keyPEM := generateSelfSignedCert(t)
+
+	container, err := redpanda.StartContainer(ctx, redpanda.WithTLS(certPEM, keyPEM))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	adminAddr, err := container.AdminAPIAddress(ctx)
+	require.NoError(t, err)
+	require.Contains(t, adminAddr, "https://")
+
+	tlsConfig, err := container.TLSConfig()
+	require.NoError(t, err)
+
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := httpClient.Get(adminAddr + "/v1/cluster/health_overview")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRedpandaWithTLSSchemaRegistryAndAdminAPI(t *testing.T) {
+	// The admin API listener is always TLS-enabled when WithTLS() is used,
+	// even without any WithTLSListeners() restriction on the Kafka API
+	// listeners, so service account bootstrapping (which goes through the
+	// admin API) must also succeed here.
+	ctx := context.Background()
+
+	certPEM, keyPEM := generateSelfSignedCert(t)
+
+	container, err := redpanda.StartContainer(
+		ctx,
+		redpanda.WithTLS(certPEM, keyPEM),
+		redpanda.WithNewServiceAccount("superuser-1", "test"),
+		redpanda.WithAutoCreateTopics(redpanda.TopicSpec{Name: "tls-topic", Partitions: 1, ReplicationFactor: 1}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, container.Terminate(ctx)) })
+
+	schemaRegistryAddr, err := container.SchemaRegistryAddress(ctx)
+	require.NoError(t, err)
+	require.Contains(t, schemaRegistryAddr, "https://")
+
+	_, err = tls.Dial("tcp", schemaRegistryAddr[len("https://"):], &tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+	require.NoError(t, err)
+}